@@ -16,10 +16,15 @@
 package gcp
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -32,9 +37,11 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/ReneKroon/ttlcache/v2"
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/pkg/errors"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
 	"github.com/sigstore/sigstore/pkg/signature/options"
 )
 
@@ -50,6 +57,11 @@ const (
 	Algorithm_RSA_PSS_3072_SHA256      = "rsa-pss-3072-sha256"
 	Algorithm_RSA_PSS_4096_SHA256      = "rsa-pss-4096-sha256"
 	Algorithm_RSA_PSS_4096_SHA512      = "rsa-pss-4096-sha512"
+	Algorithm_HMAC_SHA256              = "hmac-sha256"
+	Algorithm_HMAC_SHA384              = "hmac-sha384"
+	Algorithm_HMAC_SHA512              = "hmac-sha512"
+	Algorithm_ED25519                  = "ed25519"
+	Algorithm_ECDSA_SECP256K1_SHA256   = "ecdsa-secp256k1-sha256"
 )
 
 type gcpClient struct {
@@ -157,7 +169,9 @@ func (g *gcpClient) keyVersionName(ctx context.Context) (*cryptoKeyVersion, erro
 	if err != nil {
 		return nil, err
 	}
-	if key.Purpose != kmspb.CryptoKey_ASYMMETRIC_SIGN {
+	switch key.Purpose {
+	case kmspb.CryptoKey_ASYMMETRIC_SIGN, kmspb.CryptoKey_MAC:
+	default:
 		return nil, errors.New("specified key cannot be used to sign")
 	}
 
@@ -190,17 +204,26 @@ func (g *gcpClient) keyVersionName(ctx context.Context) (*cryptoKeyVersion, erro
 		CryptoKeyVersion: kv,
 	}
 
-	pubKey, err := g.fetchPublicKey(ctx, kv.Name)
+	// MAC keys have no public component for GCP to hand back: both signing
+	// and verification have to round-trip to KMS via MacSign/MacVerify.
+	if isMACAlgorithm(kv.Algorithm) {
+		crv.SignerVerifier = newMACSignerVerifier(g, kv.Name)
+		crv.HashFunc = crypto.Hash(0)
+		return &crv, nil
+	}
+
+	pubKey, err := g.fetchPublicKey(ctx, kv.Name, kv.Algorithm)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to fetch public key while creating signer")
 	}
 
 	var rsaPriv *rsa.PrivateKey
 	var ecPriv *ecdsa.PrivateKey
+	var edPriv ed25519.PrivateKey
 
 	switch kv.Algorithm {
 	case kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256:
-	case kmspb.CryptoKeyVersion_EC_SIGN_P384_SHA384:
+	case kmspb.CryptoKeyVersion_EC_SIGN_P384_SHA384, kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256:
 		ecPub := pubKey.(*ecdsa.PublicKey)
 		ecPriv = &ecdsa.PrivateKey{PublicKey: *ecPub}
 	case kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256, kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_3072_SHA256,
@@ -209,6 +232,12 @@ func (g *gcpClient) keyVersionName(ctx context.Context) (*cryptoKeyVersion, erro
 		kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA256, kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA512:
 		rsaPub := pubKey.(*rsa.PublicKey)
 		rsaPriv = &rsa.PrivateKey{PublicKey: *rsaPub}
+	case kmspb.CryptoKeyVersion_EC_SIGN_ED25519:
+		edPub := pubKey.(ed25519.PublicKey)
+		// there is no private scalar to recover from KMS; Public() only
+		// ever reads the last SeedSize bytes, so a zero seed is fine here
+		edPriv = make(ed25519.PrivateKey, ed25519.PrivateKeySize)
+		copy(edPriv[ed25519.SeedSize:], edPub)
 	default:
 		return nil, errors.New("unknown algorithm specified by KMS")
 	}
@@ -224,6 +253,9 @@ func (g *gcpClient) keyVersionName(ctx context.Context) (*cryptoKeyVersion, erro
 	case kmspb.CryptoKeyVersion_EC_SIGN_P384_SHA384:
 		crv.SignerVerifier, err = signature.LoadECDSASignerVerifier(ecPriv, crypto.SHA384)
 		crv.HashFunc = crypto.SHA384
+	case kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256:
+		crv.SignerVerifier, err = signature.LoadECDSASignerVerifier(ecPriv, crypto.SHA256)
+		crv.HashFunc = crypto.SHA256
 	case kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256,
 		kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_3072_SHA256,
 		kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA256:
@@ -240,6 +272,9 @@ func (g *gcpClient) keyVersionName(ctx context.Context) (*cryptoKeyVersion, erro
 	case kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA512:
 		crv.SignerVerifier, err = signature.LoadRSAPSSSignerVerifier(rsaPriv, crypto.SHA512, nil)
 		crv.HashFunc = crypto.SHA512
+	case kmspb.CryptoKeyVersion_EC_SIGN_ED25519:
+		crv.SignerVerifier, err = signature.LoadED25519SignerVerifier(edPriv)
+		crv.HashFunc = crypto.Hash(0)
 	default:
 		return nil, errors.New("unknown algorithm specified by KMS")
 	}
@@ -249,7 +284,18 @@ func (g *gcpClient) keyVersionName(ctx context.Context) (*cryptoKeyVersion, erro
 	return &crv, nil
 }
 
-func (g *gcpClient) fetchPublicKey(ctx context.Context, name string) (crypto.PublicKey, error) {
+// isMACAlgorithm reports whether alg is one of GCP KMS's symmetric MAC
+// (HMAC) algorithms, as opposed to an asymmetric signing algorithm.
+func isMACAlgorithm(alg kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm) bool {
+	switch alg {
+	case kmspb.CryptoKeyVersion_HMAC_SHA256, kmspb.CryptoKeyVersion_HMAC_SHA384, kmspb.CryptoKeyVersion_HMAC_SHA512:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *gcpClient) fetchPublicKey(ctx context.Context, name string, alg kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm) (crypto.PublicKey, error) {
 	// Build the request.
 	pkreq := &kmspb.GetPublicKeyRequest{Name: name}
 	// Call the API.
@@ -257,9 +303,52 @@ func (g *gcpClient) fetchPublicKey(ctx context.Context, name string) (crypto.Pub
 	if err != nil {
 		return nil, errors.Wrap(err, "public key")
 	}
+
+	// cryptoutils.UnmarshalPEMToPublicKey goes through
+	// x509.ParsePKIXPublicKey, which only recognizes the NIST P-curves and
+	// rejects secp256k1's OID outright, so that key needs its own parser.
+	if alg == kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256 {
+		return parseSecp256k1PublicKeyPEM([]byte(pk.GetPem()))
+	}
+
 	return cryptoutils.UnmarshalPEMToPublicKey([]byte(pk.GetPem()))
 }
 
+// oidSecp256k1 is the namedCurve OID for secp256k1 (SEC 2 §2.4.1).
+var oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+// parseSecp256k1PublicKeyPEM parses a PEM-encoded SubjectPublicKeyInfo
+// holding a secp256k1 point. It exists because x509.ParsePKIXPublicKey
+// only understands the curves in its own NIST-only registry.
+func parseSecp256k1PublicKeyPEM(pemBytes []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("decoding secp256k1 public key PEM")
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(block.Bytes, &spki); err != nil {
+		return nil, errors.Wrap(err, "parsing secp256k1 SubjectPublicKeyInfo")
+	}
+
+	var curveOID asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curveOID); err != nil {
+		return nil, errors.Wrap(err, "parsing secp256k1 curve parameters")
+	}
+	if !curveOID.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("unexpected curve OID %s for a secp256k1 key", curveOID)
+	}
+
+	pub, err := btcec.ParsePubKey(spki.PublicKey.RightAlign())
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing secp256k1 point")
+	}
+	return pub.ToECDSA(), nil
+}
+
 func (g *gcpClient) getHashFunc() (crypto.Hash, error) {
 	ckv, err := g.getCKV()
 	if err != nil {
@@ -286,30 +375,49 @@ func (g *gcpClient) sign(ctx context.Context, digest []byte, alg crypto.Hash, cr
 		return nil, err
 	}
 
-	gcpSignReq := kmspb.AsymmetricSignRequest{
-		Name:   ckv.CryptoKeyVersion.Name,
-		Digest: &kmspb.Digest{},
+	// HMAC keys have no pre-hashed digest to sign over: the caller hands us
+	// the raw message whenever HashFunc is 0, so route through the same
+	// macSignerVerifier used for verification instead of a second,
+	// digest-oriented MacSign call that could be fed the wrong bytes.
+	if isMACAlgorithm(ckv.CryptoKeyVersion.Algorithm) {
+		return ckv.SignerVerifier.SignMessage(bytes.NewReader(digest), options.WithContext(ctx))
 	}
 
-	if crc != 0 {
-		gcpSignReq.DigestCrc32C = wrapperspb.Int64(int64(crc))
+	gcpSignReq := kmspb.AsymmetricSignRequest{
+		Name: ckv.CryptoKeyVersion.Name,
 	}
 
-	switch alg {
-	case crypto.SHA256:
-		gcpSignReq.Digest.Digest = &kmspb.Digest_Sha256{
-			Sha256: digest,
+	// Ed25519 is not pre-hashed: GCP signs over the full message, passed in
+	// Data rather than a Digest. Every other algorithm gets a digest in the
+	// hash algorithm it was created with.
+	isEd25519 := ckv.CryptoKeyVersion.Algorithm == kmspb.CryptoKeyVersion_EC_SIGN_ED25519
+	if isEd25519 {
+		gcpSignReq.Data = digest
+		if crc != 0 {
+			gcpSignReq.DataCrc32C = wrapperspb.Int64(int64(crc))
 		}
-	case crypto.SHA384:
-		gcpSignReq.Digest.Digest = &kmspb.Digest_Sha384{
-			Sha384: digest,
+	} else {
+		gcpSignReq.Digest = &kmspb.Digest{}
+		if crc != 0 {
+			gcpSignReq.DigestCrc32C = wrapperspb.Int64(int64(crc))
 		}
-	case crypto.SHA512:
-		gcpSignReq.Digest.Digest = &kmspb.Digest_Sha512{
-			Sha512: digest,
+
+		switch alg {
+		case crypto.SHA256:
+			gcpSignReq.Digest.Digest = &kmspb.Digest_Sha256{
+				Sha256: digest,
+			}
+		case crypto.SHA384:
+			gcpSignReq.Digest.Digest = &kmspb.Digest_Sha384{
+				Sha384: digest,
+			}
+		case crypto.SHA512:
+			gcpSignReq.Digest.Digest = &kmspb.Digest_Sha512{
+				Sha512: digest,
+			}
+		default:
+			return nil, errors.New("unsupported hash function")
 		}
-	default:
-		return nil, errors.New("unsupported hash function")
 	}
 
 	resp, err := g.kmsClient.AsymmetricSign(ctx, &gcpSignReq)
@@ -320,8 +428,14 @@ func (g *gcpClient) sign(ctx context.Context, digest []byte, alg crypto.Hash, cr
 	// Optional, but recommended: perform integrity verification on result.
 	// For more details on ensuring E2E in-transit integrity to and from Cloud KMS visit:
 	// https://cloud.google.com/kms/docs/data-integrity-guidelines
-	if crc != 0 && !resp.VerifiedDigestCrc32C {
-		return nil, fmt.Errorf("AsymmetricSign: request corrupted in-transit")
+	if crc != 0 {
+		verified := resp.VerifiedDigestCrc32C
+		if isEd25519 {
+			verified = resp.VerifiedDataCrc32C
+		}
+		if !verified {
+			return nil, fmt.Errorf("AsymmetricSign: request corrupted in-transit")
+		}
 	}
 	if int64(crc32.Checksum(resp.Signature, crc32.MakeTable(crc32.Castagnoli))) != resp.SignatureCrc32C.Value {
 		return nil, fmt.Errorf("AsymmetricSign: response corrupted in-transit")
@@ -359,7 +473,68 @@ func (g *gcpClient) verify(sig, message io.Reader, opts ...signature.VerifyOptio
 	return nil
 }
 
-func (g *gcpClient) createKey(ctx context.Context, algorithm string) (crypto.PublicKey, error) {
+// algorithmMap maps sigstore's provider-agnostic algorithm identifiers
+// onto the GCP KMS CryptoKeyVersionAlgorithm enum. It is shared by
+// createKey and ImportKey, since both need to translate the same
+// identifier space into GCP's wire representation.
+var algorithmMap = map[string]kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm{
+	Algorithm_ECDSA_P256_SHA256:        kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
+	Algorithm_ECDSA_P384_SHA384:        kmspb.CryptoKeyVersion_EC_SIGN_P384_SHA384,
+	Algorithm_RSA_PKCS1v15_2048_SHA256: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256,
+	Algorithm_RSA_PKCS1v15_3072_SHA256: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_3072_SHA256,
+	Algorithm_RSA_PKCS1v15_4096_SHA256: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA256,
+	Algorithm_RSA_PKCS1v15_4096_SHA512: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA512,
+	Algorithm_RSA_PSS_2048_SHA256:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_2048_SHA256,
+	Algorithm_RSA_PSS_3072_SHA256:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_3072_SHA256,
+	Algorithm_RSA_PSS_4096_SHA256:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA256,
+	Algorithm_RSA_PSS_4096_SHA512:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA512,
+	Algorithm_HMAC_SHA256:              kmspb.CryptoKeyVersion_HMAC_SHA256,
+	Algorithm_HMAC_SHA384:              kmspb.CryptoKeyVersion_HMAC_SHA384,
+	Algorithm_HMAC_SHA512:              kmspb.CryptoKeyVersion_HMAC_SHA512,
+	Algorithm_ED25519:                  kmspb.CryptoKeyVersion_EC_SIGN_ED25519,
+	Algorithm_ECDSA_SECP256K1_SHA256:   kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256,
+}
+
+// gcpAlgorithmRegistry declares the algorithm/ProtectionLevel combinations
+// createKey can provision. HSM-backed keys are available for every
+// asymmetric algorithm GCP KMS supports; MAC keys are software-only. Key
+// size, for RSA, is baked into the algorithm identifier itself (GCP has no
+// way to request an RSA modulus independent of its CryptoKeyVersionAlgorithm
+// enum), so there is no separate bit-size dimension here.
+var gcpAlgorithmRegistry = kms.NewAlgorithmRegistry([]kms.SupportedAlgorithm{
+	{Algorithm: Algorithm_ECDSA_P256_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_ECDSA_P384_SHA384, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PKCS1v15_2048_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PKCS1v15_3072_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PKCS1v15_4096_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PKCS1v15_4096_SHA512, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PSS_2048_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PSS_3072_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PSS_4096_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_RSA_PSS_4096_SHA512, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_HMAC_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware}},
+	{Algorithm: Algorithm_HMAC_SHA384, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware}},
+	{Algorithm: Algorithm_HMAC_SHA512, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware}},
+	{Algorithm: Algorithm_ED25519, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+	{Algorithm: Algorithm_ECDSA_SECP256K1_SHA256, ProtectionLevels: []kms.ProtectionLevel{kms.ProtectionLevelSoftware, kms.ProtectionLevelHSM}},
+})
+
+// protectionLevelToGCP maps sigstore's provider-agnostic ProtectionLevel
+// onto the GCP KMS protobuf enum.
+func protectionLevelToGCP(pl kms.ProtectionLevel) kmspb.ProtectionLevel {
+	switch pl {
+	case kms.ProtectionLevelSoftware:
+		return kmspb.ProtectionLevel_SOFTWARE
+	case kms.ProtectionLevelHSM:
+		return kmspb.ProtectionLevel_HSM
+	case kms.ProtectionLevelExternal:
+		return kmspb.ProtectionLevel_EXTERNAL
+	default:
+		return kmspb.ProtectionLevel_PROTECTION_LEVEL_UNSPECIFIED
+	}
+}
+
+func (g *gcpClient) createKey(ctx context.Context, algorithm string, opts ...kms.CreateKeyOption) (crypto.PublicKey, error) {
 	if err := g.createKeyRing(ctx); err != nil {
 		return nil, errors.Wrap(err, "creating key ring")
 	}
@@ -371,30 +546,28 @@ func (g *gcpClient) createKey(ctx context.Context, algorithm string) (crypto.Pub
 		return g.public(ctx)
 	}
 
-	var algorithmMap = map[string]kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm{
-		Algorithm_ECDSA_P256_SHA256:        kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
-		Algorithm_ECDSA_P384_SHA384:        kmspb.CryptoKeyVersion_EC_SIGN_P384_SHA384,
-		Algorithm_RSA_PKCS1v15_2048_SHA256: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_2048_SHA256,
-		Algorithm_RSA_PKCS1v15_3072_SHA256: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_3072_SHA256,
-		Algorithm_RSA_PKCS1v15_4096_SHA256: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA256,
-		Algorithm_RSA_PKCS1v15_4096_SHA512: kmspb.CryptoKeyVersion_RSA_SIGN_PKCS1_4096_SHA512,
-		Algorithm_RSA_PSS_2048_SHA256:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_2048_SHA256,
-		Algorithm_RSA_PSS_3072_SHA256:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_3072_SHA256,
-		Algorithm_RSA_PSS_4096_SHA256:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA256,
-		Algorithm_RSA_PSS_4096_SHA512:      kmspb.CryptoKeyVersion_RSA_SIGN_PSS_4096_SHA512,
-	}
-
 	if _, ok := algorithmMap[algorithm]; !ok {
 		return nil, errors.New("unknown algorithm requested")
 	}
 
+	keyOpts := kms.ApplyCreateKeyOptions(opts...)
+	if err := gcpAlgorithmRegistry.Validate(algorithm, keyOpts); err != nil {
+		return nil, err
+	}
+
+	purpose := kmspb.CryptoKey_ASYMMETRIC_SIGN
+	if isMACAlgorithm(algorithmMap[algorithm]) {
+		purpose = kmspb.CryptoKey_MAC
+	}
+
 	createKeyRequest := &kmspb.CreateCryptoKeyRequest{
 		Parent:      fmt.Sprintf("projects/%s/locations/%s/keyRings/%s", g.projectID, g.locationID, g.keyRing),
 		CryptoKeyId: g.keyName,
 		CryptoKey: &kmspb.CryptoKey{
-			Purpose: kmspb.CryptoKey_ASYMMETRIC_SIGN,
+			Purpose: purpose,
 			VersionTemplate: &kmspb.CryptoKeyVersionTemplate{
-				Algorithm: algorithmMap[algorithm],
+				Algorithm:       algorithmMap[algorithm],
+				ProtectionLevel: protectionLevelToGCP(keyOpts.ProtectionLevel),
 			},
 		},
 	}
@@ -421,4 +594,4 @@ func (g *gcpClient) createKeyRing(ctx context.Context) error {
 	result, err := g.kmsClient.CreateKeyRing(ctx, createKeyRingRequest)
 	log.Printf("Created key ring %s in GCP KMS.\n", result.GetName())
 	return err
-}
\ No newline at end of file
+}
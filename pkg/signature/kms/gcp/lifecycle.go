@@ -0,0 +1,149 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/iterator"
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+)
+
+// Rotate creates a new, primary CryptoKeyVersion under g's key, using the
+// same algorithm as the version currently in use, and returns its public
+// key. It implements kms.KeyLifecycle.
+func (g *gcpClient) Rotate(ctx context.Context) (crypto.PublicKey, error) {
+	current, err := g.getCKV()
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching current key version")
+	}
+
+	parent := fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", g.projectID, g.locationID, g.keyRing, g.keyName)
+
+	req := &kmspb.CreateCryptoKeyVersionRequest{
+		Parent: parent,
+		CryptoKeyVersion: &kmspb.CryptoKeyVersion{
+			Algorithm: current.CryptoKeyVersion.Algorithm,
+		},
+	}
+	kv, err := g.kmsClient.CreateCryptoKeyVersion(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "rotating crypto key")
+	}
+
+	_ = g.kvCache.Remove(CacheKey)
+
+	// a new asymmetric version has no public key until generation finishes
+	for kv.State == kmspb.CryptoKeyVersion_PENDING_GENERATION {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+		kv, err = g.kmsClient.GetCryptoKeyVersion(ctx, &kmspb.GetCryptoKeyVersionRequest{Name: kv.Name})
+		if err != nil {
+			return nil, errors.Wrap(err, "polling new crypto key version")
+		}
+	}
+
+	return g.fetchPublicKey(ctx, kv.Name, kv.Algorithm)
+}
+
+// Disable marks the given key version as disabled. version is the full
+// resource name of a CryptoKeyVersion, as returned by ListVersions. It
+// implements kms.KeyLifecycle.
+func (g *gcpClient) Disable(ctx context.Context, version string) error {
+	return g.setVersionState(ctx, version, kmspb.CryptoKeyVersion_DISABLED)
+}
+
+// Enable reverses Disable. It implements kms.KeyLifecycle.
+func (g *gcpClient) Enable(ctx context.Context, version string) error {
+	return g.setVersionState(ctx, version, kmspb.CryptoKeyVersion_ENABLED)
+}
+
+func (g *gcpClient) setVersionState(ctx context.Context, version string, state kmspb.CryptoKeyVersion_CryptoKeyVersionState) error {
+	req := &kmspb.UpdateCryptoKeyVersionRequest{
+		CryptoKeyVersion: &kmspb.CryptoKeyVersion{
+			Name:  version,
+			State: state,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"state"}},
+	}
+	if _, err := g.kmsClient.UpdateCryptoKeyVersion(ctx, req); err != nil {
+		return errors.Wrap(err, "updating crypto key version state")
+	}
+
+	_ = g.kvCache.Remove(CacheKey)
+
+	return nil
+}
+
+// Destroy schedules the given key version for destruction. version is the
+// full resource name of a CryptoKeyVersion, as returned by ListVersions.
+// It implements kms.KeyLifecycle.
+func (g *gcpClient) Destroy(ctx context.Context, version string) error {
+	req := &kmspb.DestroyCryptoKeyVersionRequest{
+		Name: version,
+	}
+	if _, err := g.kmsClient.DestroyCryptoKeyVersion(ctx, req); err != nil {
+		return errors.Wrap(err, "destroying crypto key version")
+	}
+
+	_ = g.kvCache.Remove(CacheKey)
+
+	return nil
+}
+
+// ListVersions returns every key version under g's key. It implements
+// kms.KeyLifecycle.
+func (g *gcpClient) ListVersions(ctx context.Context) ([]kms.KeyVersion, error) {
+	parent := fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", g.projectID, g.locationID, g.keyRing, g.keyName)
+
+	it := g.kmsClient.ListCryptoKeyVersions(ctx, &kmspb.ListCryptoKeyVersionsRequest{Parent: parent})
+
+	var versions []kms.KeyVersion
+	for {
+		kv, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "listing crypto key versions")
+		}
+
+		v := kms.KeyVersion{
+			Name:      kv.Name,
+			State:     kv.State.String(),
+			Algorithm: kv.Algorithm.String(),
+		}
+		if kv.CreateTime != nil {
+			v.CreateTime = kv.CreateTime.AsTime()
+		}
+		if kv.DestroyTime != nil {
+			v.DestroyTime = kv.DestroyTime.AsTime()
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,30 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import "context"
+
+// Importer is implemented by KMS providers that support bringing
+// externally generated key material into the provider, e.g. GCP KMS's
+// ImportJob-based bring-your-own-key workflow. Providers that do not
+// support BYOK return ErrNotImplemented.
+type Importer interface {
+	// ImportKey wraps and uploads pem, a PEM or DER encoded private key,
+	// as a new key version under the provider-specific algorithm
+	// identifier alg. It returns the provider-specific resource name of
+	// the newly created key version.
+	ImportKey(ctx context.Context, alg string, pem []byte) (string, error)
+}
@@ -0,0 +1,61 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"time"
+)
+
+// KeyVersion describes one key version returned by KeyLifecycle.ListVersions.
+type KeyVersion struct {
+	// Name is the provider-specific resource name of the version.
+	Name string
+	// State is the provider-specific lifecycle state, e.g. "ENABLED".
+	State string
+	// Algorithm is the provider-specific algorithm identifier of the version.
+	Algorithm string
+	// CreateTime is when the version was created.
+	CreateTime time.Time
+	// DestroyTime is when the version was, or will be, destroyed. It is the
+	// zero Time if the version is not scheduled for destruction.
+	DestroyTime time.Time
+}
+
+// KeyLifecycle is implemented by KMS providers that support managing the
+// lifecycle of key versions beyond creation: rotating to a new primary
+// version, disabling or re-enabling a version, and scheduling one for
+// destruction. Providers that don't support a given operation return
+// ErrNotImplemented.
+type KeyLifecycle interface {
+	// Rotate creates a new primary key version under the existing key and
+	// returns its public key.
+	Rotate(ctx context.Context) (crypto.PublicKey, error)
+	// Disable marks the given key version as disabled, so it can no longer
+	// be used to sign or verify. version is a provider-specific resource
+	// name as returned in KeyVersion.Name by ListVersions, not a bare
+	// version id.
+	Disable(ctx context.Context, version string) error
+	// Enable reverses Disable.
+	Enable(ctx context.Context, version string) error
+	// Destroy schedules the given key version for destruction. version is
+	// a provider-specific resource name as returned in KeyVersion.Name by
+	// ListVersions, not a bare version id.
+	Destroy(ctx context.Context, version string) error
+	// ListVersions returns every key version under the configured key.
+	ListVersions(ctx context.Context) ([]KeyVersion, error)
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import "errors"
+
+// ErrNotImplemented is returned by CreateKey when a provider cannot satisfy
+// a requested ProtectionLevel or algorithm/bit-size combination.
+var ErrNotImplemented = errors.New("not implemented")
+
+// ProtectionLevel indicates the degree of physical protection a provider
+// should use to generate and store a key created via CreateKey.
+type ProtectionLevel int
+
+const (
+	// ProtectionLevelUnspecified leaves the choice of protection level up
+	// to the provider's own default.
+	ProtectionLevelUnspecified ProtectionLevel = iota
+	// ProtectionLevelSoftware generates and stores the key in software.
+	ProtectionLevelSoftware
+	// ProtectionLevelHSM generates and stores the key in a hardware
+	// security module.
+	ProtectionLevelHSM
+	// ProtectionLevelExternal generates and stores the key outside of the
+	// provider's own infrastructure.
+	ProtectionLevelExternal
+)
+
+// CreateKeyOptions holds the parameters a CreateKeyOption configures. A
+// provider's CreateKey builds one from the options passed to it and
+// consults the fields it supports.
+type CreateKeyOptions struct {
+	ProtectionLevel ProtectionLevel
+}
+
+// CreateKeyOption configures optional parameters for a provider's
+// CreateKey, such as the ProtectionLevel to provision.
+type CreateKeyOption func(*CreateKeyOptions)
+
+// WithProtectionLevel requests that CreateKey provision the key with the
+// given ProtectionLevel. Providers that cannot satisfy the requested level
+// return ErrNotImplemented.
+func WithProtectionLevel(protectionLevel ProtectionLevel) CreateKeyOption {
+	return func(o *CreateKeyOptions) {
+		o.ProtectionLevel = protectionLevel
+	}
+}
+
+// ApplyCreateKeyOptions builds a CreateKeyOptions from the given options.
+func ApplyCreateKeyOptions(opts ...CreateKeyOption) CreateKeyOptions {
+	var o CreateKeyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// SupportedAlgorithm describes one algorithm a provider's CreateKey can
+// provision, together with the ProtectionLevels it is available at. Key
+// size, where applicable (e.g. an RSA modulus), is part of the algorithm
+// identifier itself rather than a separate dimension, since every provider
+// in this package binds size to a fixed algorithm enum value.
+type SupportedAlgorithm struct {
+	// Algorithm is the provider-specific algorithm identifier, e.g.
+	// "rsa-pkcs1v15-2048-sha256".
+	Algorithm string
+	// ProtectionLevels lists the levels this algorithm can be provisioned
+	// at.
+	ProtectionLevels []ProtectionLevel
+}
+
+// AlgorithmRegistry lets a provider declare the algorithm/bit/
+// ProtectionLevel combinations its CreateKey can provision, and validate a
+// request against that declaration.
+type AlgorithmRegistry struct {
+	supported []SupportedAlgorithm
+}
+
+// NewAlgorithmRegistry builds an AlgorithmRegistry from the given
+// SupportedAlgorithm entries.
+func NewAlgorithmRegistry(supported []SupportedAlgorithm) *AlgorithmRegistry {
+	return &AlgorithmRegistry{supported: supported}
+}
+
+// Validate returns nil if algorithm can be provisioned with the given
+// options, and ErrNotImplemented otherwise.
+func (r *AlgorithmRegistry) Validate(algorithm string, opts CreateKeyOptions) error {
+	for _, s := range r.supported {
+		if s.Algorithm != algorithm {
+			continue
+		}
+		if opts.ProtectionLevel == ProtectionLevelUnspecified {
+			return nil
+		}
+		for _, pl := range s.ProtectionLevels {
+			if pl == opts.ProtectionLevel {
+				return nil
+			}
+		}
+	}
+	return ErrNotImplemented
+}
@@ -0,0 +1,199 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sshkms adapts a sigstore KMS-backed signature.SignerVerifier to
+// the golang.org/x/crypto/ssh.Signer interface, so that keys held in a
+// cloud KMS (GCP, AWS, Azure, ...) can be used to sign SSH certificates.
+package sshkms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// Signer adapts a signature.SignerVerifier from sigstore's KMS registry to
+// ssh.Signer (and ssh.AlgorithmSigner), so it can sign SSH certificates.
+type Signer struct {
+	sv     signature.SignerVerifier
+	sshPub ssh.PublicKey
+}
+
+// NewSigner builds a Signer from any sigstore KMS SignerVerifier, such as
+// one returned by kms.Get. The underlying public key must be RSA or
+// ECDSA; a SignerVerifier with no public key (e.g. an HMAC key) is
+// rejected, since SSH signatures are always asymmetric.
+func NewSigner(sv signature.SignerVerifier) (*Signer, error) {
+	pub, err := sv.PublicKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching public key")
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return nil, errors.Wrap(err, "converting public key to ssh.PublicKey")
+	}
+
+	return &Signer{sv: sv, sshPub: sshPub}, nil
+}
+
+// PublicKey implements ssh.Signer.
+func (s *Signer) PublicKey() ssh.PublicKey {
+	return s.sshPub
+}
+
+// Sign implements ssh.Signer, using the default signature algorithm for
+// the wrapped key's type.
+func (s *Signer) Sign(rand io.Reader, data []byte) (*ssh.Signature, error) {
+	return s.SignWithAlgorithm(rand, data, "")
+}
+
+// SignWithAlgorithm implements ssh.AlgorithmSigner. For RSA keys, algorithm
+// requests one of "rsa-sha2-256"/"rsa-sha2-512"; an empty algorithm picks
+// the default. If the wrapped SignerVerifier can report the hash it
+// actually signs with, that hash wins instead, since the RSA hash for a
+// KMS-backed key is fixed at key-load time and a mismatched label would
+// make the resulting signature fail SSH verification.
+func (s *Signer) SignWithAlgorithm(_ io.Reader, data []byte, algorithm string) (*ssh.Signature, error) {
+	cryptoPub, ok := s.sshPub.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T for ssh signing", s.sshPub)
+	}
+
+	switch cryptoPub.CryptoPublicKey().(type) {
+	case *ecdsa.PublicKey:
+		return s.signECDSA(data)
+	case *rsa.PublicKey:
+		return s.signRSA(data, algorithm)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T for ssh signing", cryptoPub.CryptoPublicKey())
+	}
+}
+
+func (s *Signer) signECDSA(data []byte) (*ssh.Signature, error) {
+	rawSig, err := s.sv.SignMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "signing")
+	}
+
+	// GCP (and most other KMS backends) returns ECDSA signatures as an
+	// ASN.1 SEQUENCE{r, s}; SSH wants the pair as its own mpint encoding.
+	var parsed struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(rawSig, &parsed); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling ASN.1 ECDSA signature")
+	}
+
+	blob := ssh.Marshal(struct {
+		R, S *big.Int
+	}{parsed.R, parsed.S})
+
+	return &ssh.Signature{
+		Format: s.sshPub.Type(),
+		Blob:   blob,
+	}, nil
+}
+
+// hashFuncReporter is implemented by SignerVerifiers that can report the
+// crypto.Hash they were configured to sign with at key-load time, such as
+// this module's KMS-backed RSA SignerVerifiers. Its hash, when available,
+// takes precedence over algorithm: an SSH signature labeled rsa-sha2-256
+// but carrying a SHA-512 digest fails verification, so the label must
+// match what the key actually signs with, not what the caller asked for.
+type hashFuncReporter interface {
+	HashFunc() crypto.Hash
+}
+
+func (s *Signer) signRSA(data []byte, algorithm string) (*ssh.Signature, error) {
+	// SSH's rsa-sha2-256/rsa-sha2-512 formats are defined as RSASSA-PKCS1-v1_5;
+	// a PSS key produces a differently padded signature that would be
+	// mislabeled by either format, so refuse it outright rather than
+	// emitting an ssh.Signature that looks well-formed but fails
+	// verification. HashFunc alone can't make this distinction, since both
+	// padding schemes can use the same hash.
+	if _, ok := s.sv.(*signature.RSAPKCS1v15SignerVerifier); !ok {
+		return nil, fmt.Errorf("ssh signing requires a PKCS1v15 RSA SignerVerifier, got %T", s.sv)
+	}
+
+	format := algorithm
+	if hr, ok := s.sv.(hashFuncReporter); ok {
+		switch h := hr.HashFunc(); h {
+		case crypto.SHA256:
+			format = ssh.SigAlgoRSASHA2256
+		case crypto.SHA512:
+			format = ssh.SigAlgoRSASHA2512
+		default:
+			return nil, fmt.Errorf("unsupported rsa hash %s for ssh signing", h)
+		}
+	} else if format == "" {
+		format = ssh.SigAlgoRSASHA2256
+	}
+
+	switch format {
+	case ssh.SigAlgoRSASHA2256, ssh.SigAlgoRSASHA2512:
+	default:
+		return nil, fmt.Errorf("unsupported rsa ssh signature algorithm %q", format)
+	}
+
+	sig, err := s.sv.SignMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "signing")
+	}
+
+	return &ssh.Signature{
+		Format: format,
+		Blob:   sig,
+	}, nil
+}
+
+// MultiAlgorithmSigner extends Signer with ssh.MultiAlgorithmSigner,
+// advertising which SSH signature algorithms the wrapped key supports so
+// an ssh client or CA can select one explicitly.
+type MultiAlgorithmSigner struct {
+	*Signer
+	algorithms []string
+}
+
+// NewMultiAlgorithmSigner builds a MultiAlgorithmSigner from any sigstore
+// KMS SignerVerifier, see NewSigner.
+func NewMultiAlgorithmSigner(sv signature.SignerVerifier) (*MultiAlgorithmSigner, error) {
+	s, err := NewSigner(sv)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithms := []string{s.sshPub.Type()}
+	if s.sshPub.Type() == ssh.KeyAlgoRSA {
+		algorithms = []string{ssh.SigAlgoRSASHA2256, ssh.SigAlgoRSASHA2512}
+	}
+
+	return &MultiAlgorithmSigner{Signer: s, algorithms: algorithms}, nil
+}
+
+// Algorithms implements ssh.MultiAlgorithmSigner.
+func (m *MultiAlgorithmSigner) Algorithms() []string {
+	return m.algorithms
+}